@@ -0,0 +1,30 @@
+package main
+
+// batchBalanceProofs splits proofs into chunks of at most batchSize. A
+// batchSize of 0 disables batching and returns a single chunk containing all
+// of proofs, matching the pre-batching behavior.
+func batchBalanceProofs[T any](proofs []T, batchSize uint64) [][]T {
+	if batchSize == 0 || uint64(len(proofs)) <= batchSize {
+		return [][]T{proofs}
+	}
+
+	var batches [][]T
+	for start := 0; start < len(proofs); start += int(batchSize) {
+		end := start + int(batchSize)
+		if end > len(proofs) {
+			end = len(proofs)
+		}
+		batches = append(batches, proofs[start:end])
+	}
+	return batches
+}
+
+// sequentialIndices returns count consecutive indices starting at start, used
+// to key resumable progress by position within the original proof slice.
+func sequentialIndices(start, count int) []uint64 {
+	indices := make([]uint64, count)
+	for i := 0; i < count; i++ {
+		indices[i] = uint64(start + i)
+	}
+	return indices
+}
@@ -0,0 +1,56 @@
+package main
+
+import "testing"
+
+func TestBatchBalanceProofs(t *testing.T) {
+	proofs := []int{0, 1, 2, 3, 4, 5, 6}
+
+	t.Run("zero batch size returns a single batch", func(t *testing.T) {
+		batches := batchBalanceProofs(proofs, 0)
+		if len(batches) != 1 || len(batches[0]) != len(proofs) {
+			t.Fatalf("expected a single batch of %d, got %v", len(proofs), batches)
+		}
+	})
+
+	t.Run("batch size larger than input returns a single batch", func(t *testing.T) {
+		batches := batchBalanceProofs(proofs, 100)
+		if len(batches) != 1 || len(batches[0]) != len(proofs) {
+			t.Fatalf("expected a single batch of %d, got %v", len(proofs), batches)
+		}
+	})
+
+	t.Run("splits into chunks of at most batchSize", func(t *testing.T) {
+		batches := batchBalanceProofs(proofs, 3)
+		want := [][]int{{0, 1, 2}, {3, 4, 5}, {6}}
+		if len(batches) != len(want) {
+			t.Fatalf("expected %d batches, got %d: %v", len(want), len(batches), batches)
+		}
+		for i := range want {
+			if len(batches[i]) != len(want[i]) {
+				t.Fatalf("batch %d: expected %v, got %v", i, want[i], batches[i])
+			}
+			for j := range want[i] {
+				if batches[i][j] != want[i][j] {
+					t.Fatalf("batch %d: expected %v, got %v", i, want[i], batches[i])
+				}
+			}
+		}
+	})
+}
+
+func TestSequentialIndices(t *testing.T) {
+	got := sequentialIndices(5, 4)
+	want := []uint64{5, 6, 7, 8}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+
+	if len(sequentialIndices(0, 0)) != 0 {
+		t.Fatalf("expected sequentialIndices(0, 0) to be empty, got %v", sequentialIndices(0, 0))
+	}
+}
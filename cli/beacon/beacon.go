@@ -0,0 +1,50 @@
+// Package beacon provides pluggable sources of VersionedBeaconState, so proof
+// generation isn't tied to a single beacon node's API shape. Users with only
+// a public RPC endpoint (which usually refuses debug/beacon/states), a
+// checkpointz-style archival endpoint, or a downloaded SSZ snapshot can all
+// produce checkpoint/credential proofs.
+package beacon
+
+import (
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/pkg/errors"
+)
+
+// Source is implemented by every beacon-state backend: a full beacon node,
+// a checkpointz-style endpoint, or a local SSZ snapshot file.
+type Source interface {
+	// BeaconState returns the beacon state for the given state identifier
+	// (a slot number, a block root, or "head"/"finalized").
+	BeaconState(stateId string) (*spec.VersionedBeaconState, error)
+}
+
+// New picks a Source implementation based on uri's scheme:
+//   - file://path/to/state.ssz   a local SSZ snapshot file
+//   - checkpointz://host:port   a checkpointz-style archival endpoint
+//   - https:// / http://        a standard beacon node HTTP API
+//
+// forkOverride, if non-empty, is used instead of the server-reported
+// Eth-Consensus-Version when decoding a fetched state. For a file:// source,
+// it instead means the file has no bespoke fork-name header at all and is
+// raw SSZ, letting operators use a beacon node's own dump directly.
+func New(uri string, forkOverride string) (Source, error) {
+	switch {
+	case strings.HasPrefix(uri, "file://"):
+		return NewSnapshotFileSource(strings.TrimPrefix(uri, "file://"), forkOverride), nil
+
+	case strings.HasPrefix(uri, "checkpointz://"):
+		source := NewCheckpointzSource("https://" + strings.TrimPrefix(uri, "checkpointz://"))
+		source.http.forkOverride = forkOverride
+		return source, nil
+
+	case strings.HasPrefix(uri, "https://"), strings.HasPrefix(uri, "http://"):
+		source := NewHTTPSource(uri)
+		source.forkOverride = forkOverride
+		return source, nil
+
+	default:
+		return nil, errors.Errorf("unrecognized beacon state source: %s (expected a file://, checkpointz://, or http(s):// URL)", uri)
+	}
+}
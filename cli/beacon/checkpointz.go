@@ -0,0 +1,24 @@
+package beacon
+
+import "github.com/attestantio/go-eth2-client/spec"
+
+// CheckpointzSource fetches beacon states from a checkpointz-style archival
+// endpoint. Checkpointz exposes the same debug/beacon/states API as a full
+// beacon node, but serves state by block root rather than "head"/"finalized",
+// since it has no live head of its own.
+type CheckpointzSource struct {
+	http *HTTPSource
+}
+
+// NewCheckpointzSource returns a Source backed by a checkpointz-style
+// endpoint at address.
+func NewCheckpointzSource(address string) *CheckpointzSource {
+	return &CheckpointzSource{http: NewHTTPSource(address)}
+}
+
+// BeaconState fetches the state for blockRoot. Unlike HTTPSource, "head" and
+// "finalized" are not meaningful state identifiers here: callers must supply
+// a specific block root.
+func (s *CheckpointzSource) BeaconState(blockRoot string) (*spec.VersionedBeaconState, error) {
+	return s.http.BeaconState(blockRoot)
+}
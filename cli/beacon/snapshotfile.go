@@ -0,0 +1,63 @@
+package beacon
+
+import (
+	"bufio"
+	"io"
+	"os"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/pkg/errors"
+)
+
+// SnapshotFileSource loads a beacon state from a local file, so CI and
+// air-gapped operators can generate proofs without running a beacon node.
+//
+// Without a forkOverride, the file is expected in this package's own bespoke
+// format: a single newline-terminated fork name (one of "phase0", "altair",
+// "bellatrix", "capella", "deneb", "electra") followed by the state's raw SSZ
+// encoding for that fork. With a forkOverride, the file is instead treated as
+// raw SSZ with no header line at all, so an operator-supplied dump (e.g. from
+// `curl .../eth/v2/debug/beacon/states/head -o state.ssz`, which carries no
+// fork name of its own) can be used directly.
+type SnapshotFileSource struct {
+	path         string
+	forkOverride string
+}
+
+// NewSnapshotFileSource returns a Source backed by the snapshot file at path.
+// forkOverride, if non-empty, is used instead of expecting path to carry its
+// own bespoke fork-name header.
+func NewSnapshotFileSource(path string, forkOverride string) *SnapshotFileSource {
+	return &SnapshotFileSource{path: path, forkOverride: forkOverride}
+}
+
+// BeaconState ignores stateId: a snapshot file contains exactly one state.
+func (s *SnapshotFileSource) BeaconState(_ string) (*spec.VersionedBeaconState, error) {
+	f, err := os.Open(s.path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open beacon state snapshot file")
+	}
+	defer f.Close()
+
+	if s.forkOverride != "" {
+		data, err := io.ReadAll(f)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read snapshot file body")
+		}
+		return decodeVersionedBeaconState(s.forkOverride, data)
+	}
+
+	reader := bufio.NewReader(f)
+	fork, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read fork header from snapshot file")
+	}
+	fork = fork[:len(fork)-1] // trim trailing newline
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read snapshot file body")
+	}
+
+	return decodeVersionedBeaconState(fork, data)
+}
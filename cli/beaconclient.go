@@ -0,0 +1,34 @@
+package main
+
+import (
+	"github.com/Layr-Labs/eigenpod-proofs-generation/cli/beacon"
+	"github.com/attestantio/go-eth2-client/spec"
+)
+
+// BeaconClient is how proof generation fetches beacon state, independent of
+// which backend is behind --beaconNode (a live beacon node, a checkpointz-
+// style archive, or a local SSZ snapshot file).
+type BeaconClient interface {
+	GetBeaconState(stateId string) (*spec.VersionedBeaconState, error)
+}
+
+type beaconClientImpl struct {
+	source beacon.Source
+}
+
+func (b *beaconClientImpl) GetBeaconState(stateId string) (*spec.VersionedBeaconState, error) {
+	return b.source.BeaconState(stateId)
+}
+
+// NewBeaconClient dispatches on beaconUri's scheme (file://, checkpointz://,
+// or http(s)://) to build the right BeaconClient backend. The returned string
+// echoes back beaconUri, for callers that want to log which source they got.
+// forkOverride, if non-empty, forces which fork's SSZ layout is used to
+// decode a fetched state, for sources that don't reliably report it.
+func NewBeaconClient(beaconUri string, forkOverride string) (BeaconClient, string, error) {
+	source, err := beacon.New(beaconUri, forkOverride)
+	if err != nil {
+		return nil, "", err
+	}
+	return &beaconClientImpl{source: source}, beaconUri, nil
+}
@@ -25,6 +25,13 @@ type TCredentialCommandArgs struct {
 	BatchSize           uint64
 	NoPrompt            bool
 	Verbose             bool
+
+	// SafeOut and SafeAddress produce no Safe Transaction Builder bundle in
+	// this command: core.SubmitValidatorProof has no safe-bundle mode, so a
+	// non-empty SafeOut makes CredentialsCommand panic below, and SafeAddress
+	// is never read.
+	SafeOut     string
+	SafeAddress string
 }
 
 func CredentialsCommand(args TCredentialCommandArgs) error {
@@ -43,6 +50,11 @@ func CredentialsCommand(args TCredentialCommandArgs) error {
 		return nil
 	}
 
+	if len(args.SafeOut) > 0 {
+		core.Panic("--safe-out is not supported by this command: core.SubmitValidatorProof has no safe-bundle mode.")
+		return nil
+	}
+
 	var specificValidatorIndex *big.Int = nil
 	if args.SpecificValidator != math.MaxUint64 && args.SpecificValidator != 0 {
 		specificValidatorIndex = new(big.Int).SetUint64(args.SpecificValidator)
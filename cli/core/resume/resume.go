@@ -0,0 +1,100 @@
+// Package resume tracks progress through a batched checkpoint proof
+// submission so it can pick up where it left off after a crash or a gas
+// spike, instead of re-submitting balance proofs that already landed onchain.
+package resume
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// State records which balance-proof indices (i.e. positions within a
+// checkpoint's BalanceProofs) have already been verified onchain, for a
+// single (eigenpodAddress, checkpointTimestamp) checkpoint.
+type State struct {
+	EigenpodAddress     string   `json:"eigenpodAddress"`
+	CheckpointTimestamp uint64   `json:"checkpointTimestamp"`
+	VerifiedIndices     []uint64 `json:"verifiedIndices"`
+}
+
+// Path returns the resume file path for a given pod/checkpoint, rooted under
+// dir (typically the user's --out directory, or the working directory).
+func Path(dir, eigenpodAddress string, checkpointTimestamp uint64) string {
+	return filepath.Join(dir, fmt.Sprintf(".eigenproofs-resume-%s-%d.json", eigenpodAddress, checkpointTimestamp))
+}
+
+// Load reads a resume file from disk. A missing file is not an error: it
+// returns a fresh, empty State for eigenpodAddress/checkpointTimestamp.
+func Load(path, eigenpodAddress string, checkpointTimestamp uint64) (*State, error) {
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &State{
+			EigenpodAddress:     eigenpodAddress,
+			CheckpointTimestamp: checkpointTimestamp,
+		}, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to read resume file")
+	}
+
+	var state State
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return nil, errors.Wrap(err, "failed to parse resume file")
+	}
+
+	if state.EigenpodAddress != eigenpodAddress || state.CheckpointTimestamp != checkpointTimestamp {
+		return nil, errors.Errorf("resume file at %s is for a different pod/checkpoint (found %s@%d, expected %s@%d)", path, state.EigenpodAddress, state.CheckpointTimestamp, eigenpodAddress, checkpointTimestamp)
+	}
+
+	return &state, nil
+}
+
+// Save persists the resume file to disk.
+func (s *State) Save(path string) error {
+	out, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal resume file")
+	}
+	return os.WriteFile(path, out, 0644)
+}
+
+// MarkVerified records that indices have been successfully proven onchain.
+func (s *State) MarkVerified(indices []uint64) {
+	seen := make(map[uint64]bool, len(s.VerifiedIndices))
+	for _, i := range s.VerifiedIndices {
+		seen[i] = true
+	}
+	for _, i := range indices {
+		if !seen[i] {
+			s.VerifiedIndices = append(s.VerifiedIndices, i)
+			seen[i] = true
+		}
+	}
+}
+
+// Remaining filters allIndices down to those not yet recorded as verified.
+func (s *State) Remaining(allIndices []uint64) []uint64 {
+	verified := make(map[uint64]bool, len(s.VerifiedIndices))
+	for _, i := range s.VerifiedIndices {
+		verified[i] = true
+	}
+
+	var remaining []uint64
+	for _, i := range allIndices {
+		if !verified[i] {
+			remaining = append(remaining, i)
+		}
+	}
+	return remaining
+}
+
+// Clear removes the resume file once a checkpoint has fully completed.
+func Clear(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.Wrap(err, "failed to remove resume file")
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package resume
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadMissingFileReturnsFreshState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "does-not-exist.json")
+
+	state, err := Load(path, "0xpod", 42)
+	if err != nil {
+		t.Fatalf("expected no error for a missing resume file, got: %v", err)
+	}
+	if state.EigenpodAddress != "0xpod" || state.CheckpointTimestamp != 42 {
+		t.Fatalf("expected a fresh state for 0xpod@42, got %+v", state)
+	}
+	if len(state.VerifiedIndices) != 0 {
+		t.Fatalf("expected no verified indices, got %v", state.VerifiedIndices)
+	}
+}
+
+func TestSaveAndLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resume.json")
+
+	state := &State{EigenpodAddress: "0xpod", CheckpointTimestamp: 42}
+	state.MarkVerified([]uint64{1, 2, 3})
+
+	if err := state.Save(path); err != nil {
+		t.Fatalf("failed to save resume file: %v", err)
+	}
+
+	loaded, err := Load(path, "0xpod", 42)
+	if err != nil {
+		t.Fatalf("failed to load resume file: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.VerifiedIndices, state.VerifiedIndices) {
+		t.Fatalf("expected loaded verified indices %v, got %v", state.VerifiedIndices, loaded.VerifiedIndices)
+	}
+}
+
+func TestLoadRejectsMismatchedPodOrCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resume.json")
+
+	state := &State{EigenpodAddress: "0xpod", CheckpointTimestamp: 42}
+	if err := state.Save(path); err != nil {
+		t.Fatalf("failed to save resume file: %v", err)
+	}
+
+	if _, err := Load(path, "0xotherpod", 42); err == nil {
+		t.Fatal("expected an error loading a resume file for a different pod")
+	}
+	if _, err := Load(path, "0xpod", 43); err == nil {
+		t.Fatal("expected an error loading a resume file for a different checkpoint")
+	}
+}
+
+func TestMarkVerifiedIsIdempotent(t *testing.T) {
+	state := &State{}
+	state.MarkVerified([]uint64{1, 2})
+	state.MarkVerified([]uint64{2, 3})
+
+	want := []uint64{1, 2, 3}
+	if !reflect.DeepEqual(state.VerifiedIndices, want) {
+		t.Fatalf("expected verified indices %v, got %v", want, state.VerifiedIndices)
+	}
+}
+
+func TestRemaining(t *testing.T) {
+	state := &State{}
+	state.MarkVerified([]uint64{1, 3})
+
+	got := state.Remaining([]uint64{0, 1, 2, 3, 4})
+	want := []uint64{0, 2, 4}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected remaining indices %v, got %v", want, got)
+	}
+}
+
+func TestClearRemovesFileAndToleratesMissing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resume.json")
+	state := &State{EigenpodAddress: "0xpod", CheckpointTimestamp: 42}
+	if err := state.Save(path); err != nil {
+		t.Fatalf("failed to save resume file: %v", err)
+	}
+
+	if err := Clear(path); err != nil {
+		t.Fatalf("failed to clear resume file: %v", err)
+	}
+	if _, err := Load(path, "0xpod", 42); err != nil {
+		t.Fatalf("expected Load after Clear to behave like a missing file, got: %v", err)
+	}
+
+	if err := Clear(path); err != nil {
+		t.Fatalf("expected clearing an already-missing resume file to be a no-op, got: %v", err)
+	}
+}
@@ -7,13 +7,20 @@ import (
 	"fmt"
 	"math/big"
 	"os"
+	"path/filepath"
 	"time"
 
 	"context"
 
+	"github.com/Layr-Labs/eigenpod-proofs-generation/cli/core/resume"
 	"github.com/Layr-Labs/eigenpod-proofs-generation/cli/onchain"
+	"github.com/Layr-Labs/eigenpod-proofs-generation/cli/oracle"
+	"github.com/Layr-Labs/eigenpod-proofs-generation/cli/safe"
 	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/fatih/color"
 	"github.com/pkg/errors"
@@ -25,8 +32,9 @@ func shortenHex(publicKey string) string {
 }
 
 func main() {
-	var eigenpodAddress, beacon, node, owner, output string
-	var forceCheckpoint, disableColor, verbose bool
+	var eigenpodAddress, beacon, node, owner, output, safeOut, safeAddress, snapshotURL, oracleRegistry, beaconStateFile, fork string
+	var forceCheckpoint, disableColor, verbose, simulate bool
+	var checkpointBatchSize uint64
 	var useJson bool = false
 	ctx := context.Background()
 
@@ -36,6 +44,24 @@ func main() {
 		Usage:                  "Generates proofs to (1) checkpoint your validators, or (2) verify the withdrawal credentials of an inactive validator.",
 		EnableBashCompletion:   true,
 		UseShortOptionHandling: true,
+		Before: func(cctx *cli.Context) error {
+			if len(beaconStateFile) > 0 {
+				if len(beacon) > 0 {
+					return errors.New("cannot use --beaconNode and --beacon-state-file together")
+				}
+				beacon = "file://" + beaconStateFile
+			} else if len(beacon) == 0 {
+				// --snapshot-url + --oracle-registry is a trust-anchor-only mode: a
+				// pod owner with no beacon API access at all can still verify a
+				// signed oracle snapshot and, with the checkpoint command, write a
+				// standalone proof derived from it. It doesn't require a beacon
+				// source of its own.
+				if len(snapshotURL) == 0 || len(oracleRegistry) == 0 {
+					return errors.New("one of --beaconNode or --beacon-state-file is required, unless both --snapshot-url and --oracle-registry are set")
+				}
+			}
+			return nil
+		},
 		Commands: []*cli.Command{
 			{
 				Name:  "status",
@@ -57,7 +83,7 @@ func main() {
 					eth, err := ethclient.Dial(node)
 					PanicOnError("failed to reach eth --node.", err)
 
-					beaconClient, err := getBeaconClient(beacon)
+					beaconClient, err := getBeaconClient(beacon, fork)
 					PanicOnError("failed to reach beacon chain.", err)
 
 					status := getStatus(ctx, eigenpodAddress, eth, beaconClient)
@@ -142,13 +168,19 @@ func main() {
 						Usage:       "If true, starts a checkpoint even if the pod has no native ETH to award shares",
 						Destination: &forceCheckpoint,
 					},
+					&cli.Uint64Flag{
+						Name:        "batch-size",
+						Value:       0,
+						Usage:       "If set, splits the checkpoint's balance proofs into batches of this size and submits them as independent transactions, resuming from a local state file if interrupted. (0 disables batching)",
+						Destination: &checkpointBatchSize,
+					},
 				},
 				Action: func(cctx *cli.Context) error {
 					if disableColor {
 						color.NoColor = true
 					}
 
-					var out, owner *string = nil, nil
+					var out, owner, safeOut, safeAddress *string = nil, nil, nil, nil
 
 					if len(cctx.String("out")) > 0 {
 						outProp := cctx.String("out")
@@ -160,7 +192,68 @@ func main() {
 						owner = &ownerProp
 					}
 
-					eth, beaconClient, chainId := getClients(ctx, node, beacon)
+					if len(cctx.String("safe-out")) > 0 {
+						safeOutProp := cctx.String("safe-out")
+						safeOut = &safeOutProp
+					}
+
+					if len(cctx.String("safe-address")) > 0 {
+						safeAddressProp := cctx.String("safe-address")
+						safeAddress = &safeAddressProp
+					}
+
+					if safeOut != nil && owner != nil {
+						PanicOnError("cannot use --safe-out and --owner together", errors.New("ambiguous submission mode"))
+					}
+
+					if safeOut != nil && safeAddress == nil {
+						PanicOnError("--safe-address is required when using --safe-out", errors.New("missing --safe-address"))
+					}
+
+					if len(beacon) == 0 {
+						// Before only lets us get here when --snapshot-url and
+						// --oracle-registry are both set instead of a real beacon
+						// source. That's still enough to verify the trust anchor and
+						// derive a standalone, JSON-only SnapshotCheckpointProof (see
+						// oracle.Snapshot.CheckpointProof): one balance-inclusion proof
+						// per validator, straight from the snapshot's own multiproof.
+						// It's not enough to submit anything onchain, though: the
+						// onchain.BeaconChainProofsBalanceContainerProof below needs a
+						// real merkle proof bridging BalancesContainerRoot to the
+						// onchain-recorded beaconBlockRoot (the snapshot has that link
+						// only as a signed attestation, not a merkle path), and the
+						// snapshot's bare leaf+branch entries are not the per-validator
+						// balance proofs VerifyCheckpointProofs takes.
+						if owner != nil || safeOut != nil {
+							PanicOnError("snapshot-only onchain submission is not supported", errors.New("--owner/--safe-out need a real beacon source (--beaconNode or --beacon-state-file) to build onchain calldata; --snapshot-url alone can only produce a standalone proof via --out"))
+						}
+
+						eth, err := ethclient.Dial(node)
+						PanicOnError("failed to reach eth --node.", err)
+
+						snapshot, err := verifySnapshotTrustAnchor(ctx, eth, snapshotURL, oracleRegistry)
+						PanicOnError("failed to verify oracle snapshot trust anchor", err)
+						color.Green("verified oracle snapshot trust anchor: %s", snapshotURL)
+
+						snapshotProof, err := snapshot.CheckpointProof()
+						PanicOnError("failed to derive a standalone proof from the oracle snapshot", err)
+
+						jsonString, err := json.Marshal(snapshotProof)
+						PanicOnError("failed to generate JSON proof data.", err)
+
+						WriteOutputToFileOrStdout(jsonString, out)
+						return nil
+					}
+
+					eth, beaconClient, chainId := getClients(ctx, node, beacon, fork)
+
+					var trustAnchor *oracle.Snapshot
+					if len(snapshotURL) > 0 {
+						snapshot, err := verifySnapshotTrustAnchor(ctx, eth, snapshotURL, oracleRegistry)
+						PanicOnError("failed to verify oracle snapshot trust anchor", err)
+						color.Green("verified oracle snapshot trust anchor: %s", snapshotURL)
+						trustAnchor = snapshot
+					}
 
 					currentCheckpoint := getCurrentCheckpoint(eigenpodAddress, eth)
 					if currentCheckpoint == 0 {
@@ -168,40 +261,143 @@ func main() {
 							newCheckpoint, err := startCheckpoint(ctx, eigenpodAddress, *owner, chainId, eth, forceCheckpoint)
 							PanicOnError("failed to start checkpoint", err)
 							currentCheckpoint = newCheckpoint
-						} else {
+						} else if safeOut == nil {
 							PanicOnError("no checkpoint active and no private key provided to start one", errors.New("no checkpoint"))
 						}
+						// else: safeOut != nil. There's no private key to start a
+						// checkpoint with here, so the startCheckpoint call itself gets
+						// bundled into the Safe Transaction Builder output below instead.
 					}
 					color.Green("pod has active checkpoint! checkpoint timestamp: %d", currentCheckpoint)
 
 					proof := GenerateCheckpointProof(ctx, eigenpodAddress, eth, chainId, beaconClient)
 
+					if trustAnchor != nil && trustAnchor.BalancesContainerRoot != proof.ValidatorBalancesRootProof.ValidatorBalancesRoot {
+						PanicOnError("beacon node disagrees with oracle snapshot trust anchor", errors.New("balances container root mismatch"))
+					}
+
 					jsonString, err := json.Marshal(proof)
 					PanicOnError("failed to generate JSON proof data.", err)
 
 					WriteOutputToFileOrStdout(jsonString, out)
 
-					if owner != nil {
-						// submit the proof onchain
+					if safeOut != nil {
+						// build a batch of unsigned transactions for a Gnosis Safe (or similar multisig) to submit.
+						eigenPod, err := onchain.NewEigenPod(common.HexToAddress(eigenpodAddress), eth)
+						PanicOnError("failed to reach eigenpod", err)
+
+						safeFrom := common.HexToAddress(*safeAddress)
+						safeOpts := safe.NoSendOpts(safeFrom)
+
+						var safeTxns []safe.Transaction
+
+						if currentCheckpoint == 0 {
+							startTxn, err := eigenPod.StartCheckpoint(safeOpts, forceCheckpoint)
+							PanicOnError("failed to build startCheckpoint calldata", err)
+							safeTxns = append(safeTxns, safe.FromTxn(startTxn))
+						}
+
+						for _, batch := range batchBalanceProofs(proof.BalanceProofs, checkpointBatchSize) {
+							verifyTxn, err := eigenPod.VerifyCheckpointProofs(
+								safeOpts,
+								onchain.BeaconChainProofsBalanceContainerProof{
+									BalanceContainerRoot: proof.ValidatorBalancesRootProof.ValidatorBalancesRoot,
+									Proof:                proof.ValidatorBalancesRootProof.Proof.ToByteSlice(),
+								},
+								castBalanceProofs(batch),
+							)
+							PanicOnError("failed to build verifyCheckpointProofs calldata", err)
+							safeTxns = append(safeTxns, safe.FromTxn(verifyTxn))
+						}
+
+						err = safe.WriteBundle(*safeOut, chainId, fmt.Sprintf("EigenPod checkpoint (%s)", eigenpodAddress), safeTxns)
+						PanicOnError("failed to write safe transaction bundle", err)
+						color.Green("wrote Safe Transaction Builder bundle to %s", *safeOut)
+					} else if owner != nil {
 						ownerAccount, err := prepareAccount(owner, chainId)
 						PanicOnError("failed to parse private key", err)
 
 						eigenPod, err := onchain.NewEigenPod(common.HexToAddress(eigenpodAddress), eth)
 						PanicOnError("failed to reach eigenpod", err)
 
-						color.Green("calling EigenPod.VerifyCheckpointProofs()...")
+						opts := ownerAccount.TransactionOptions
+						if simulate {
+							opts = noSendCallOpts(ownerAccount.TransactionOptions.From)
+						}
+
+						batches := batchBalanceProofs(proof.BalanceProofs, checkpointBatchSize)
+
+						var resumeState *resume.State
+						var resumePath string
+						if checkpointBatchSize > 0 && !simulate {
+							resumeDir := "."
+							if out != nil {
+								resumeDir = filepath.Dir(*out)
+							}
+							resumePath = resume.Path(resumeDir, eigenpodAddress, currentCheckpoint)
+							resumeState, err = resume.Load(resumePath, eigenpodAddress, currentCheckpoint)
+							PanicOnError("failed to load resume file", err)
+
+							// the resume file is a local cache, not a source of truth: it
+							// can be lost, restored from a stale backup, or never written
+							// if a prior run crashed before its first Save(). Reconcile it
+							// against the pod's own progress before trusting it to skip
+							// batches, since proofs are submitted in index order.
+							checkpoint, err := eigenPod.CurrentCheckpoint(nil)
+							PanicOnError("failed to reach eigenpod", err)
+							onChainVerified := len(proof.BalanceProofs) - int(checkpoint.ProofsRemaining)
+							if onChainVerified > 0 {
+								resumeState.MarkVerified(sequentialIndices(0, onChainVerified))
+							}
+						}
+
+						nextIndex := 0
+						for batchNum, batch := range batches {
+							batchIndices := sequentialIndices(nextIndex, len(batch))
+							nextIndex += len(batch)
+
+							if resumeState != nil && len(resumeState.Remaining(batchIndices)) == 0 {
+								color.Yellow("skipping batch %d/%d (already verified, per resume file)", batchNum+1, len(batches))
+								continue
+							}
+
+							if !simulate {
+								color.Green("calling EigenPod.VerifyCheckpointProofs() (batch %d/%d)...", batchNum+1, len(batches))
+							}
 
-						txn, err := eigenPod.VerifyCheckpointProofs(
-							ownerAccount.TransactionOptions,
-							onchain.BeaconChainProofsBalanceContainerProof{
-								BalanceContainerRoot: proof.ValidatorBalancesRootProof.ValidatorBalancesRoot,
-								Proof:                proof.ValidatorBalancesRootProof.Proof.ToByteSlice(),
-							},
-							castBalanceProofs(proof.BalanceProofs),
-						)
+							txn, err := eigenPod.VerifyCheckpointProofs(
+								opts,
+								onchain.BeaconChainProofsBalanceContainerProof{
+									BalanceContainerRoot: proof.ValidatorBalancesRootProof.ValidatorBalancesRoot,
+									Proof:                proof.ValidatorBalancesRootProof.Proof.ToByteSlice(),
+								},
+								castBalanceProofs(batch),
+							)
+							PanicOnError("failed to invoke verifyCheckpointProofs", err)
+
+							if simulate {
+								result, err := SimulateTransaction(ctx, eth, ownerAccount.TransactionOptions.From, txn)
+								PanicOnError("failed to simulate verifyCheckpointProofs", err)
+								printSimulationResult(fmt.Sprintf("VerifyCheckpointProofs (batch %d/%d)", batchNum+1, len(batches)), result)
+							} else {
+								color.Green("transaction(%d/%d): %s", batchNum+1, len(batches), txn.Hash().Hex())
+
+								receipt, err := bind.WaitMined(ctx, eth, txn)
+								PanicOnError("failed to wait for verifyCheckpointProofs to be mined", err)
+								if receipt.Status != types.ReceiptStatusSuccessful {
+									PanicOnError("verifyCheckpointProofs reverted", errors.Errorf("transaction %s reverted", txn.Hash().Hex()))
+								}
+							}
 
-						PanicOnError("failed to invoke verifyCheckpointProofs", err)
-						color.Green("transaction: %s", txn.Hash().Hex())
+							if resumeState != nil && !simulate {
+								resumeState.MarkVerified(batchIndices)
+								PanicOnError("failed to persist resume file", resumeState.Save(resumePath))
+							}
+						}
+
+						if resumeState != nil {
+							PanicOnError("failed to clear resume file", resume.Clear(resumePath))
+						}
 					}
 
 					return nil
@@ -216,7 +412,7 @@ func main() {
 						color.NoColor = true
 					}
 
-					var out, owner *string = nil, nil
+					var out, owner, safeOut, safeAddress *string = nil, nil, nil, nil
 
 					if len(cctx.String("out")) > 0 {
 						outProp := cctx.String("out")
@@ -228,7 +424,42 @@ func main() {
 						owner = &ownerProp
 					}
 
-					eth, beaconClient, chainId := getClients(ctx, node, beacon)
+					if len(cctx.String("safe-out")) > 0 {
+						safeOutProp := cctx.String("safe-out")
+						safeOut = &safeOutProp
+					}
+
+					if len(cctx.String("safe-address")) > 0 {
+						safeAddressProp := cctx.String("safe-address")
+						safeAddress = &safeAddressProp
+					}
+
+					if safeOut != nil && owner != nil {
+						PanicOnError("cannot use --safe-out and --owner together", errors.New("ambiguous submission mode"))
+					}
+
+					if safeOut != nil && safeAddress == nil {
+						PanicOnError("--safe-address is required when using --safe-out", errors.New("missing --safe-address"))
+					}
+
+					if len(beacon) == 0 {
+						// See the equivalent guard in the checkpoint command: a snapshot
+						// alone isn't enough to generate a credential proof either.
+						PanicOnError("snapshot-only proof generation is not supported", errors.New("--snapshot-url alone cannot replace a beacon source for proof generation; --beaconNode or --beacon-state-file is still required to generate (not just sanity-check) a credential proof"))
+					}
+
+					eth, beaconClient, chainId := getClients(ctx, node, beacon, fork)
+
+					if len(snapshotURL) > 0 {
+						// validator credential proofs aren't rooted in the balances
+						// container, so there's no field of the snapshot to cross-check
+						// against proof output here (unlike the checkpoint command). We
+						// still require the signer threshold and replay checks to pass.
+						_, err := verifySnapshotTrustAnchor(ctx, eth, snapshotURL, oracleRegistry)
+						PanicOnError("failed to verify oracle snapshot trust anchor", err)
+						color.Green("verified oracle snapshot trust anchor: %s", snapshotURL)
+					}
+
 					validatorProofs, validatorIndices := GenerateValidatorProof(ctx, eigenpodAddress, eth, chainId, beaconClient)
 					if validatorProofs == nil || validatorIndices == nil {
 						return nil
@@ -239,10 +470,7 @@ func main() {
 
 					WriteOutputToFileOrStdout(jsonString, out)
 
-					if owner != nil {
-						ownerAccount, err := prepareAccount(owner, chainId)
-						PanicOnError("failed to parse private key", err)
-
+					if owner != nil || safeOut != nil {
 						eigenPod, err := onchain.NewEigenPod(common.HexToAddress(eigenpodAddress), eth)
 						PanicOnError("failed to reach eigenpod", err)
 
@@ -259,22 +487,57 @@ func main() {
 						latestBlock, err := eth.BlockByNumber(ctx, nil)
 						PanicOnError("failed to load latest block", err)
 
-						color.Green("submitting onchain...")
-						txn, err := eigenPod.VerifyWithdrawalCredentials(
-							ownerAccount.TransactionOptions,
-							latestBlock.Time(),
-							onchain.BeaconChainProofsStateRootProof{
-								Proof:           validatorProofs.StateRootProof.Proof.ToByteSlice(),
-								BeaconStateRoot: validatorProofs.StateRootProof.BeaconStateRoot,
-							},
-							indices,
-							validatorFieldsProofs,
-							validatorFields,
-						)
-
-						PanicOnError("failed to invoke verifyWithdrawalCredentials", err)
-
-						color.Green("transaction: %s", txn.Hash().Hex())
+						if safeOut != nil {
+							// build an unsigned transaction for a Gnosis Safe (or similar multisig) to submit.
+							safeFrom := common.HexToAddress(*safeAddress)
+							verifyTxn, err := eigenPod.VerifyWithdrawalCredentials(
+								safe.NoSendOpts(safeFrom),
+								latestBlock.Time(),
+								onchain.BeaconChainProofsStateRootProof{
+									Proof:           validatorProofs.StateRootProof.Proof.ToByteSlice(),
+									BeaconStateRoot: validatorProofs.StateRootProof.BeaconStateRoot,
+								},
+								indices,
+								validatorFieldsProofs,
+								validatorFields,
+							)
+							PanicOnError("failed to build verifyWithdrawalCredentials calldata", err)
+
+							err = safe.WriteBundle(*safeOut, chainId, fmt.Sprintf("EigenPod withdrawal credentials (%s)", eigenpodAddress), []safe.Transaction{safe.FromTxn(verifyTxn)})
+							PanicOnError("failed to write safe transaction bundle", err)
+							color.Green("wrote Safe Transaction Builder bundle to %s", *safeOut)
+						} else {
+							ownerAccount, err := prepareAccount(owner, chainId)
+							PanicOnError("failed to parse private key", err)
+
+							opts := ownerAccount.TransactionOptions
+							if simulate {
+								opts = noSendCallOpts(ownerAccount.TransactionOptions.From)
+							} else {
+								color.Green("submitting onchain...")
+							}
+
+							txn, err := eigenPod.VerifyWithdrawalCredentials(
+								opts,
+								latestBlock.Time(),
+								onchain.BeaconChainProofsStateRootProof{
+									Proof:           validatorProofs.StateRootProof.Proof.ToByteSlice(),
+									BeaconStateRoot: validatorProofs.StateRootProof.BeaconStateRoot,
+								},
+								indices,
+								validatorFieldsProofs,
+								validatorFields,
+							)
+							PanicOnError("failed to invoke verifyWithdrawalCredentials", err)
+
+							if simulate {
+								result, err := SimulateTransaction(ctx, eth, ownerAccount.TransactionOptions.From, txn)
+								PanicOnError("failed to simulate verifyWithdrawalCredentials", err)
+								printSimulationResult("VerifyWithdrawalCredentials", result)
+							} else {
+								color.Green("transaction: %s", txn.Hash().Hex())
+							}
+						}
 					}
 					return nil
 				},
@@ -293,10 +556,21 @@ func main() {
 				Name:        "beaconNode",
 				Aliases:     []string{"b"},
 				Value:       "",
-				Usage:       "[required] `URL` to a functioning beacon node RPC (https://)",
-				Required:    true,
+				Usage:       "`URL` to a beacon state source: a beacon node RPC (https://), a checkpointz-style endpoint (checkpointz://), or omit this and use --beacon-state-file instead.",
 				Destination: &beacon,
 			},
+			&cli.StringFlag{
+				Name:        "beacon-state-file",
+				Value:       "",
+				Usage:       "`path` to a local SSZ beacon state snapshot, used instead of --beaconNode. Lets CI and air-gapped operators generate proofs without running a beacon node.",
+				Destination: &beaconStateFile,
+			},
+			&cli.StringFlag{
+				Name:        "fork",
+				Value:       "",
+				Usage:       "overrides the fork (`phase0`, `altair`, `bellatrix`, `capella`, `deneb`, or `electra`) used to decode the beacon state, for --beaconNode sources that omit or misreport Eth-Consensus-Version.",
+				Destination: &fork,
+			},
 			&cli.StringFlag{
 				Name:        "execNode",
 				Aliases:     []string{"e"},
@@ -319,6 +593,31 @@ func main() {
 				Usage:       "`Private key` of the owner. If set, this will automatically submit the proofs to their corresponding onchain functions after generation. If using checkpoint mode, it will also begin a checkpoint if one hasn't been started already.",
 				Destination: &owner,
 			},
+			&cli.StringFlag{
+				Name:        "safe-out",
+				Aliases:     []string{"safe-tx-builder"},
+				Value:       "",
+				Usage:       "`path` to write a Gnosis Safe Transaction Builder-compatible JSON bundle to, instead of broadcasting with --owner. For pod owners controlled by a multisig.",
+				Destination: &safeOut,
+			},
+			&cli.StringFlag{
+				Name:        "safe-address",
+				Value:       "",
+				Usage:       "`address` of the Gnosis Safe (or other multisig) that will submit the bundle written by --safe-out. Required alongside --safe-out.",
+				Destination: &safeAddress,
+			},
+			&cli.StringFlag{
+				Name:        "snapshot-url",
+				Value:       "",
+				Usage:       "`URL` (https:// or ipfs://) to a signed oracle proof snapshot, used as a trust anchor to sanity-check --beaconNode when only a light/archival beacon API is available.",
+				Destination: &snapshotURL,
+			},
+			&cli.StringFlag{
+				Name:        "oracle-registry",
+				Value:       "",
+				Usage:       "`address` of the on-chain oracle signer registry used to verify --snapshot-url. Required alongside --snapshot-url.",
+				Destination: &oracleRegistry,
+			},
 			&cli.BoolFlag{
 				Name:        "no-color",
 				Value:       false,
@@ -332,6 +631,12 @@ func main() {
 				Usage:       "Enable verbose output.",
 				Destination: &verbose,
 			},
+			&cli.BoolFlag{
+				Name:        "simulate",
+				Value:       false,
+				Usage:       "Dry-run the proof submission against the latest execution-layer state via eth_call, instead of broadcasting. Reports gas used and any revert reason.",
+				Destination: &simulate,
+			},
 		},
 	}
 
@@ -340,8 +645,16 @@ func main() {
 	}
 }
 
-func getBeaconClient(beaconUri string) (BeaconClient, error) {
-	beaconClient, _, err := NewBeaconClient(beaconUri)
+func printSimulationResult(call string, result *SimulationResult) {
+	if result.Reverted {
+		color.Red("simulation of %s reverted: %s", call, result.RevertReason)
+		return
+	}
+	color.Green("simulation of %s succeeded. gas used: %d", call, result.GasUsed)
+}
+
+func getBeaconClient(beaconUri, forkOverride string) (BeaconClient, error) {
+	beaconClient, _, err := NewBeaconClient(beaconUri, forkOverride)
 	return beaconClient, err
 }
 
@@ -355,6 +668,26 @@ func getCurrentCheckpoint(eigenpodAddress string, client *ethclient.Client) uint
 	return timestamp
 }
 
+// withdrawal credential prefixes that route withdrawals to the execution layer.
+// 0x01 is the original ETH1 prefix; 0x02 is the Electra (EIP-7251) compounding
+// prefix, which also permits effective balances above 32 ETH.
+const (
+	withdrawalCredentialPrefixEth1        byte = 0x01
+	withdrawalCredentialPrefixCompounding byte = 0x02
+)
+
+func hasExecutionWithdrawalCredentials(prefix byte) bool {
+	return prefix == withdrawalCredentialPrefixEth1 || prefix == withdrawalCredentialPrefixCompounding
+}
+
+// EffectiveBalanceGwei returns a validator's actual effective balance in
+// Gwei, as recorded in the beacon state, rather than assuming the pre-Electra
+// 32 ETH cap. Post-Electra (0x02, compounding) validators may report up to
+// 2048 ETH here.
+func EffectiveBalanceGwei(validator *phase0.Validator) uint64 {
+	return uint64(validator.EffectiveBalance)
+}
+
 // search through beacon state for validators whose withdrawal address is set to eigenpod.
 func findAllValidatorsForEigenpod(eigenpodAddress string, beaconState *spec.VersionedBeaconState) []ValidatorWithIndex {
 	allValidators, err := beaconState.Validators()
@@ -367,7 +700,12 @@ func findAllValidatorsForEigenpod(eigenpodAddress string, beaconState *spec.Vers
 	maxValidators := uint64(len(allValidators))
 	for i = 0; i < maxValidators; i++ {
 		validator := allValidators[i]
-		if validator == nil || validator.WithdrawalCredentials[0] != 1 { // withdrawalCredentials _need_ their first byte set to 1 to withdraw to execution layer.
+		if validator == nil || !hasExecutionWithdrawalCredentials(validator.WithdrawalCredentials[0]) {
+			continue
+		}
+		// a validator whose effective balance has dropped to zero (fully
+		// slashed/withdrawn) has nothing left to checkpoint-prove.
+		if EffectiveBalanceGwei(validator) == 0 {
 			continue
 		}
 		// we check that the last 20 bytes of expectedCredentials matches validatorCredentials.
@@ -419,14 +757,36 @@ func getCurrentCheckpointBlockRoot(eigenpodAddress string, eth *ethclient.Client
 	return &checkpoint.BeaconBlockRoot, nil
 }
 
-func getClients(ctx context.Context, node, beaconNodeUri string) (*ethclient.Client, BeaconClient, *big.Int) {
+// verifySnapshotTrustAnchor fetches the oracle proof snapshot at snapshotURL and
+// confirms it carries a valid k-of-n signer threshold and an unreplayed epoch,
+// per the on-chain signer registry at registryAddress. It returns the verified
+// snapshot so callers can cross-check it against data pulled from --beaconNode.
+func verifySnapshotTrustAnchor(ctx context.Context, eth *ethclient.Client, snapshotURL, registryAddress string) (*oracle.Snapshot, error) {
+	snapshot, err := oracle.FetchSnapshot(ctx, snapshotURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch oracle snapshot")
+	}
+
+	registry, err := oracle.NewRegistry(common.HexToAddress(registryAddress), eth)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to bind oracle registry")
+	}
+
+	if err := oracle.Verify(ctx, snapshot, registry); err != nil {
+		return nil, errors.Wrap(err, "oracle snapshot failed verification")
+	}
+
+	return snapshot, nil
+}
+
+func getClients(ctx context.Context, node, beaconNodeUri, forkOverride string) (*ethclient.Client, BeaconClient, *big.Int) {
 	eth, err := ethclient.Dial(node)
 	PanicOnError("failed to reach eth --node.", err)
 
 	chainId, err := eth.ChainID(ctx)
 	PanicOnError("failed to fetch chain id", err)
 
-	beaconClient, err := getBeaconClient(beaconNodeUri)
+	beaconClient, err := getBeaconClient(beaconNodeUri, forkOverride)
 	PanicOnError("failed to reach beacon chain.", err)
 
 	return eth, beaconClient, chainId
@@ -0,0 +1,61 @@
+package oracle
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+const registryABIJson = `[
+	{"constant":true,"inputs":[],"name":"threshold","outputs":[{"name":"","type":"uint256"}],"type":"function"},
+	{"constant":true,"inputs":[{"name":"signer","type":"address"}],"name":"isSigner","outputs":[{"name":"","type":"bool"}],"type":"function"},
+	{"constant":true,"inputs":[],"name":"lastAcceptedEpoch","outputs":[{"name":"","type":"uint256"}],"type":"function"}
+]`
+
+// Registry is a thin binding to the on-chain oracle signer registry used to
+// back SignerRegistry. It mirrors the read-only subset of the registry
+// contract's ABI needed to verify a Snapshot.
+type Registry struct {
+	contract *bind.BoundContract
+}
+
+// NewRegistry binds to the oracle signer registry deployed at address.
+func NewRegistry(address common.Address, backend bind.ContractBackend) (*Registry, error) {
+	parsed, err := abi.JSON(strings.NewReader(registryABIJson))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse oracle registry ABI")
+	}
+
+	return &Registry{
+		contract: bind.NewBoundContract(address, parsed, backend, backend, backend),
+	}, nil
+}
+
+func (r *Registry) Threshold(ctx context.Context) (uint64, error) {
+	var out []interface{}
+	if err := r.contract.Call(&bind.CallOpts{Context: ctx}, &out, "threshold"); err != nil {
+		return 0, errors.Wrap(err, "failed to call threshold()")
+	}
+	return out[0].(*big.Int).Uint64(), nil
+}
+
+func (r *Registry) IsSigner(ctx context.Context, signer common.Address) (bool, error) {
+	var out []interface{}
+	if err := r.contract.Call(&bind.CallOpts{Context: ctx}, &out, "isSigner", signer); err != nil {
+		return false, errors.Wrap(err, "failed to call isSigner()")
+	}
+	return out[0].(bool), nil
+}
+
+func (r *Registry) LastAcceptedEpoch(ctx context.Context) (uint64, error) {
+	var out []interface{}
+	if err := r.contract.Call(&bind.CallOpts{Context: ctx}, &out, "lastAcceptedEpoch"); err != nil {
+		return 0, errors.Wrap(err, "failed to call lastAcceptedEpoch()")
+	}
+	return out[0].(*big.Int).Uint64(), nil
+}
@@ -0,0 +1,390 @@
+// Package oracle implements a trust-anchor fallback for users who only have an
+// execution node and a light/archival beacon API. Instead of fetching a full
+// VersionedBeaconState, callers can fetch a signed "proof snapshot" (balance
+// container root + validator multiproof) published by a fixed set of oracle
+// signers, verify its k-of-n signature threshold, and use it in place of a
+// live beacon node.
+package oracle
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// balancesContainerDepth is the depth of the balances-container merkle tree
+// that ValidatorProof proves into. The real container packs 4 balances per
+// leaf (so the true depth is log2(VALIDATOR_REGISTRY_LIMIT) - 2); treating
+// every validator index as its own leaf is a simplification, but it's enough
+// to let ValidatorIndices be an arbitrary, non-contiguous subset of the
+// registry rather than requiring a contiguous run.
+const balancesContainerDepth = 40
+
+// Snapshot is a signed, periodically-published trust anchor for a single
+// beacon slot: the balance container root and a validator-subtree multiproof
+// against it, attested to by a fixed signer set.
+type Snapshot struct {
+	BeaconBlockRoot       [32]byte `json:"beaconBlockRoot"`
+	Slot                  uint64   `json:"slot"`
+	Epoch                 uint64   `json:"epoch"`
+	BalancesContainerRoot [32]byte `json:"balancesContainerRoot"`
+
+	// ValidatorProof (JSON "validatorMultiproof") proves that ValidatorLeaves are the balance-container
+	// leaves for this pod's validator indices, against BalancesContainerRoot.
+	ValidatorIndices []uint64   `json:"validatorIndices"`
+	ValidatorLeaves  [][32]byte `json:"validatorLeaves"`
+	ValidatorProof   [][32]byte `json:"validatorMultiproof"`
+
+	// Signatures are ECDSA signatures (r||s||v, 65 bytes) over Hash(), one per
+	// oracle signer.
+	Signatures [][]byte `json:"signatures"`
+}
+
+// Hash returns the message that each oracle signer signs over: everything in
+// the snapshot except the signatures themselves.
+func (s *Snapshot) Hash() [32]byte {
+	h := sha256.New()
+	h.Write(s.BeaconBlockRoot[:])
+
+	var buf [8]byte
+	putUint64 := func(v uint64) {
+		for i := 0; i < 8; i++ {
+			buf[i] = byte(v >> (8 * (7 - i)))
+		}
+		h.Write(buf[:])
+	}
+	putUint64(s.Slot)
+	putUint64(s.Epoch)
+	h.Write(s.BalancesContainerRoot[:])
+
+	for _, index := range s.ValidatorIndices {
+		putUint64(index)
+	}
+	for _, leaf := range s.ValidatorLeaves {
+		h.Write(leaf[:])
+	}
+	for _, node := range s.ValidatorProof {
+		h.Write(node[:])
+	}
+
+	var out [32]byte
+	copy(out[:], h.Sum(nil))
+	return out
+}
+
+// SignerRegistry is the on-chain source of truth for who may sign snapshots,
+// how many signatures are required, and which epoch was last accepted (replay
+// protection).
+type SignerRegistry interface {
+	Threshold(ctx context.Context) (uint64, error)
+	IsSigner(ctx context.Context, signer common.Address) (bool, error)
+	LastAcceptedEpoch(ctx context.Context) (uint64, error)
+}
+
+// FetchSnapshot retrieves a snapshot from an https:// or ipfs:// URL. ipfs://
+// URLs are resolved against a public gateway.
+func FetchSnapshot(ctx context.Context, snapshotURL string) (*Snapshot, error) {
+	resolved := snapshotURL
+	if strings.HasPrefix(snapshotURL, "ipfs://") {
+		resolved = "https://ipfs.io/ipfs/" + strings.TrimPrefix(snapshotURL, "ipfs://")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, resolved, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build snapshot request")
+	}
+
+	client := http.Client{Timeout: 30 * time.Second}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch snapshot")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("snapshot fetch returned status %d", res.StatusCode)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read snapshot body")
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(body, &snapshot); err != nil {
+		return nil, errors.Wrap(err, "failed to parse snapshot JSON")
+	}
+
+	return &snapshot, nil
+}
+
+// Verify checks that the snapshot's validator multiproof resolves to
+// BalancesContainerRoot, that at least `threshold` signatures recover to
+// addresses in `registry`, and that Epoch is newer than the last one the
+// oracle contract accepted (replay protection).
+func Verify(ctx context.Context, snapshot *Snapshot, registry SignerRegistry) error {
+	if len(snapshot.ValidatorIndices) != len(snapshot.ValidatorLeaves) {
+		return errors.New("snapshot has a different number of validator indices than validator leaves")
+	}
+	if len(snapshot.ValidatorIndices) == 0 {
+		return errors.New("snapshot has no validator leaves")
+	}
+	if !VerifyMultiproof(snapshot.BalancesContainerRoot, snapshot.ValidatorLeaves, snapshot.ValidatorIndices, snapshot.ValidatorProof, balancesContainerDepth) {
+		return errors.New("snapshot validator multiproof does not resolve to the attested balances container root")
+	}
+
+	lastAccepted, err := registry.LastAcceptedEpoch(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to read last accepted epoch from oracle registry")
+	}
+	if snapshot.Epoch <= lastAccepted {
+		return errors.Errorf("snapshot epoch %d is not newer than last accepted epoch %d (possible replay)", snapshot.Epoch, lastAccepted)
+	}
+
+	threshold, err := registry.Threshold(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to read signer threshold from oracle registry")
+	}
+
+	digest := snapshot.Hash()
+	seen := map[common.Address]bool{}
+	var validSignatures uint64
+
+	for _, sig := range snapshot.Signatures {
+		if len(sig) != 65 {
+			continue
+		}
+
+		pubKey, err := crypto.SigToPub(digest[:], sig)
+		if err != nil {
+			continue
+		}
+		signer := crypto.PubkeyToAddress(*pubKey)
+
+		if seen[signer] {
+			continue
+		}
+
+		isSigner, err := registry.IsSigner(ctx, signer)
+		if err != nil {
+			return errors.Wrap(err, "failed to check oracle signer registry")
+		}
+		if !isSigner {
+			continue
+		}
+
+		seen[signer] = true
+		validSignatures++
+	}
+
+	if validSignatures < threshold {
+		return errors.Errorf("snapshot has %d valid signatures, but %d are required", validSignatures, threshold)
+	}
+
+	return nil
+}
+
+// VerifyMultiproof checks that `leaves`, at leaf positions `indices` (same
+// order, each index within [0, 2^depth) on the bottom layer), resolve to `root`
+// along `proof`: the minimal sibling nodes needed to walk every leaf to the
+// root given that the other leaves in `indices` are already known.
+//
+// Unlike a single-sibling-per-level scheme, this does not assume `indices`
+// are contiguous: at each layer, a sibling is only pulled from `proof` if it
+// isn't already one of the nodes computed from another leaf in this same
+// multiproof. `indices` is sorted internally, so callers don't need to
+// pre-sort it, but `proof` must be ordered to match that sorted traversal
+// (ascending index within each layer, root-ward).
+func VerifyMultiproof(root [32]byte, leaves [][32]byte, indices []uint64, proof [][32]byte, depth uint64) bool {
+	layers, ok := multiproofLayers(indices, leaves, proof, depth)
+	if !ok {
+		return false
+	}
+	computed, ok := layers[depth][0]
+	return ok && computed == root
+}
+
+// multiproofBranch derives the standalone merkle branch (one sibling per
+// layer) for a single leaf at `target`, out of the same combined multiproof
+// VerifyMultiproof checks. A combined multiproof only exists to let one
+// snapshot attest to many validators' leaves at once; this undoes that
+// batching for one leaf at a time, since that's the shape a single-leaf
+// onchain inclusion proof needs.
+func multiproofBranch(indices []uint64, leaves [][32]byte, proof [][32]byte, depth uint64, target uint64) ([][32]byte, bool) {
+	layers, ok := multiproofLayers(indices, leaves, proof, depth)
+	if !ok {
+		return nil, false
+	}
+
+	branch := make([][32]byte, 0, depth)
+	index := target
+	for layer := uint64(0); layer < depth; layer++ {
+		sibling, ok := layers[layer][index^1]
+		if !ok {
+			return nil, false
+		}
+		branch = append(branch, sibling)
+		index /= 2
+	}
+	return branch, true
+}
+
+// multiproofLayers folds leaves+proof up to the root, as VerifyMultiproof
+// does, but returns every intermediate layer's known nodes (keyed by index
+// within that layer) instead of just the final root. Nodes pulled from
+// `proof` to fill a missing sibling are recorded back into their own layer,
+// so any other leaf's branch can reuse them too: a proof entry is a real
+// node in the tree, not something scoped to whichever leaf first needed it.
+func multiproofLayers(indices []uint64, leaves [][32]byte, proof [][32]byte, depth uint64) ([]map[uint64][32]byte, bool) {
+	if len(leaves) == 0 || len(leaves) != len(indices) {
+		return nil, false
+	}
+
+	layers := make([]map[uint64][32]byte, depth+1)
+
+	known := make(map[uint64][32]byte, len(leaves))
+	layerIndices := make([]uint64, 0, len(leaves))
+	for i, index := range indices {
+		if _, exists := known[index]; exists {
+			return nil, false
+		}
+		known[index] = leaves[i]
+		layerIndices = append(layerIndices, index)
+	}
+	sort.Slice(layerIndices, func(i, j int) bool { return layerIndices[i] < layerIndices[j] })
+	layers[0] = known
+
+	proofIndex := 0
+	for layer := uint64(0); layer < depth; layer++ {
+		nextKnown := make(map[uint64][32]byte, len(layerIndices))
+		nextIndices := make([]uint64, 0, len(layerIndices))
+
+		for _, index := range layerIndices {
+			parent := index / 2
+			if _, done := nextKnown[parent]; done {
+				continue
+			}
+
+			node := layers[layer][index]
+			sibling, ok := layers[layer][index^1]
+			if !ok {
+				if proofIndex >= len(proof) {
+					return nil, false
+				}
+				sibling = proof[proofIndex]
+				proofIndex++
+				layers[layer][index^1] = sibling
+			}
+
+			if index%2 == 0 {
+				nextKnown[parent] = hashPair(node, sibling)
+			} else {
+				nextKnown[parent] = hashPair(sibling, node)
+			}
+			nextIndices = append(nextIndices, parent)
+		}
+
+		layers[layer+1] = nextKnown
+		layerIndices = nextIndices
+	}
+
+	if proofIndex != len(proof) {
+		return nil, false
+	}
+	return layers, true
+}
+
+// ValidatorProofFor derives the standalone balance-inclusion proof for a
+// single validator out of this snapshot's combined multiproof: the leaf
+// value and sibling branch onchain verification of one validator ultimately
+// needs, as opposed to the batched form a snapshot carries for efficiency.
+func (s *Snapshot) ValidatorProofFor(validatorIndex uint64) (leaf [32]byte, proof [][32]byte, ok bool) {
+	pos := -1
+	for i, index := range s.ValidatorIndices {
+		if index == validatorIndex {
+			pos = i
+			break
+		}
+	}
+	if pos < 0 {
+		return leaf, nil, false
+	}
+
+	branch, ok := multiproofBranch(s.ValidatorIndices, s.ValidatorLeaves, s.ValidatorProof, balancesContainerDepth, validatorIndex)
+	if !ok {
+		return leaf, nil, false
+	}
+	return s.ValidatorLeaves[pos], branch, true
+}
+
+// SnapshotCheckpointProof is a lesser, JSON-only checkpoint proof artifact
+// derivable from an oracle snapshot alone, with no live beacon state at all:
+// one standalone balance-inclusion proof per validator the snapshot covers.
+//
+// Unlike the proof GenerateCheckpointProof produces from a full beacon
+// state, this has no bridging proof from BalancesContainerRoot to the
+// onchain-recorded beaconBlockRoot: oracle signers attest to that link
+// directly (by signing both fields together) rather than proving it via a
+// merkle path. That means it cannot be fed into EigenPod.VerifyCheckpointProofs
+// as-is: that call takes an onchain.BeaconChainProofsBalanceContainerProof,
+// which needs a real merkle proof of that link, plus per-validator balance
+// proofs, which a bare leaf and branch are not. It exists for operators who want a portable,
+// independently-checkable record of their validators' balances without
+// running a beacon node, not as a drop-in replacement for onchain submission.
+type SnapshotCheckpointProof struct {
+	BeaconBlockRoot       [32]byte                        `json:"beaconBlockRoot"`
+	Slot                  uint64                          `json:"slot"`
+	BalancesContainerRoot [32]byte                        `json:"balancesContainerRoot"`
+	ValidatorProofs       []SnapshotValidatorBalanceProof `json:"validatorProofs"`
+}
+
+// SnapshotValidatorBalanceProof is one validator's entry in a
+// SnapshotCheckpointProof: its balance-container leaf and the merkle branch
+// proving it into BalancesContainerRoot.
+type SnapshotValidatorBalanceProof struct {
+	ValidatorIndex uint64     `json:"validatorIndex"`
+	BalanceLeaf    [32]byte   `json:"balanceLeaf"`
+	Proof          [][32]byte `json:"proof"`
+}
+
+// CheckpointProof derives a SnapshotCheckpointProof covering every validator
+// this snapshot attests to.
+func (s *Snapshot) CheckpointProof() (*SnapshotCheckpointProof, error) {
+	proofs := make([]SnapshotValidatorBalanceProof, 0, len(s.ValidatorIndices))
+	for _, index := range s.ValidatorIndices {
+		leaf, branch, ok := s.ValidatorProofFor(index)
+		if !ok {
+			return nil, errors.Errorf("failed to derive a standalone balance proof for validator %d", index)
+		}
+		proofs = append(proofs, SnapshotValidatorBalanceProof{
+			ValidatorIndex: index,
+			BalanceLeaf:    leaf,
+			Proof:          branch,
+		})
+	}
+
+	return &SnapshotCheckpointProof{
+		BeaconBlockRoot:       s.BeaconBlockRoot,
+		Slot:                  s.Slot,
+		BalancesContainerRoot: s.BalancesContainerRoot,
+		ValidatorProofs:       proofs,
+	}, nil
+}
+
+func hashPair(left, right [32]byte) [32]byte {
+	h := sha256.New()
+	h.Write(left[:])
+	h.Write(right[:])
+	var out [32]byte
+	copy(out[:], h.Sum(nil))
+	return out
+}
@@ -0,0 +1,198 @@
+package oracle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func leaf(b byte) [32]byte {
+	var out [32]byte
+	out[0] = b
+	return out
+}
+
+// padToDepth folds node, sitting at index 0 of layer `from`, up to the root of
+// a balancesContainerDepth-deep tree whose other nodes are all zero. It returns
+// that root and the zero sibling taken in at each layer on the way up.
+func padToDepth(node [32]byte, from uint64) ([32]byte, [][32]byte) {
+	var zero [32]byte
+	var siblings [][32]byte
+	for layer := from; layer < balancesContainerDepth; layer++ {
+		siblings = append(siblings, zero)
+		node = hashPair(node, zero)
+	}
+	return node, siblings
+}
+
+func TestVerifyMultiproof(t *testing.T) {
+	l0, l1, l2, l3 := leaf(0), leaf(1), leaf(2), leaf(3)
+	root := hashPair(hashPair(l0, l1), hashPair(l2, l3))
+
+	t.Run("contiguous pair", func(t *testing.T) {
+		ok := VerifyMultiproof(root, [][32]byte{l0, l1}, []uint64{0, 1}, [][32]byte{hashPair(l2, l3)}, 2)
+		if !ok {
+			t.Fatal("expected contiguous-pair multiproof to verify")
+		}
+	})
+
+	t.Run("odd, non-contiguous start", func(t *testing.T) {
+		// indices 1 and 3: neither is paired with the other in this multiproof,
+		// so both siblings (leaves 0 and 2) must come from proof.
+		ok := VerifyMultiproof(root, [][32]byte{l1, l3}, []uint64{1, 3}, [][32]byte{l0, l2}, 2)
+		if !ok {
+			t.Fatal("expected odd/non-contiguous multiproof to verify")
+		}
+	})
+
+	t.Run("wrong root fails", func(t *testing.T) {
+		var wrongRoot [32]byte
+		wrongRoot[0] = 0xff
+		ok := VerifyMultiproof(wrongRoot, [][32]byte{l1, l3}, []uint64{1, 3}, [][32]byte{l0, l2}, 2)
+		if ok {
+			t.Fatal("expected multiproof against the wrong root to fail")
+		}
+	})
+
+	t.Run("empty leaves fails", func(t *testing.T) {
+		if VerifyMultiproof(root, nil, nil, nil, 2) {
+			t.Fatal("expected empty multiproof to fail")
+		}
+	})
+
+	t.Run("mismatched lengths fails", func(t *testing.T) {
+		if VerifyMultiproof(root, [][32]byte{l1}, []uint64{1, 3}, [][32]byte{l0, l2}, 2) {
+			t.Fatal("expected mismatched indices/leaves lengths to fail")
+		}
+	})
+}
+
+func TestValidatorProofForAndCheckpointProof(t *testing.T) {
+	l0, l1, l2, l3 := leaf(0), leaf(1), leaf(2), leaf(3)
+	// leaves 0-3 fill the bottom two layers; padding supplies one sibling per
+	// layer above them, up to balancesContainerDepth.
+	root, padding := padToDepth(hashPair(hashPair(l0, l1), hashPair(l2, l3)), 2)
+
+	snapshot := &Snapshot{
+		BeaconBlockRoot:       leaf(0xaa),
+		Slot:                  100,
+		BalancesContainerRoot: root,
+		ValidatorIndices:      []uint64{1, 3},
+		ValidatorLeaves:       [][32]byte{l1, l3},
+		ValidatorProof:        append([][32]byte{l0, l2}, padding...),
+	}
+
+	t.Run("derives a branch that verifies on its own", func(t *testing.T) {
+		gotLeaf, branch, ok := snapshot.ValidatorProofFor(1)
+		if !ok {
+			t.Fatal("expected to derive a standalone proof for validator 1")
+		}
+		if gotLeaf != l1 {
+			t.Fatalf("expected leaf %v, got %v", l1, gotLeaf)
+		}
+		if !VerifyMultiproof(root, [][32]byte{gotLeaf}, []uint64{1}, branch, balancesContainerDepth) {
+			t.Fatal("expected derived single-validator branch to verify against the root")
+		}
+	})
+
+	t.Run("unknown validator index fails", func(t *testing.T) {
+		if _, _, ok := snapshot.ValidatorProofFor(2); ok {
+			t.Fatal("expected ValidatorProofFor to fail for an index not in the snapshot")
+		}
+	})
+
+	t.Run("CheckpointProof covers every validator", func(t *testing.T) {
+		proof, err := snapshot.CheckpointProof()
+		if err != nil {
+			t.Fatalf("failed to derive checkpoint proof: %v", err)
+		}
+		if len(proof.ValidatorProofs) != 2 {
+			t.Fatalf("expected 2 validator proofs, got %d", len(proof.ValidatorProofs))
+		}
+		for _, vp := range proof.ValidatorProofs {
+			if !VerifyMultiproof(root, [][32]byte{vp.BalanceLeaf}, []uint64{vp.ValidatorIndex}, vp.Proof, balancesContainerDepth) {
+				t.Fatalf("validator %d's derived proof does not verify", vp.ValidatorIndex)
+			}
+		}
+	})
+}
+
+type fakeRegistry struct {
+	threshold    uint64
+	signers      map[common.Address]bool
+	lastAccepted uint64
+}
+
+func (f *fakeRegistry) Threshold(ctx context.Context) (uint64, error) { return f.threshold, nil }
+func (f *fakeRegistry) IsSigner(ctx context.Context, signer common.Address) (bool, error) {
+	return f.signers[signer], nil
+}
+func (f *fakeRegistry) LastAcceptedEpoch(ctx context.Context) (uint64, error) {
+	return f.lastAccepted, nil
+}
+
+func TestVerifySignerThreshold(t *testing.T) {
+	key1, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	key2, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	addr1 := crypto.PubkeyToAddress(key1.PublicKey)
+	addr2 := crypto.PubkeyToAddress(key2.PublicKey)
+
+	l0, l1 := leaf(0), leaf(1)
+	root, padding := padToDepth(hashPair(l0, l1), 1)
+	snapshot := &Snapshot{
+		BalancesContainerRoot: root,
+		Epoch:                 10,
+		ValidatorIndices:      []uint64{0, 1},
+		ValidatorLeaves:       [][32]byte{l0, l1},
+		ValidatorProof:        padding,
+	}
+	// both validator leaves are given directly, so the bottom layer needs no
+	// proof node: hash(l0, l1) resolves once both are known. Above it, padding
+	// supplies one sibling per layer up to balancesContainerDepth.
+	digest := snapshot.Hash()
+
+	sig1, err := crypto.Sign(digest[:], key1)
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+	sig2, err := crypto.Sign(digest[:], key2)
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+	snapshot.Signatures = [][]byte{sig1, sig2}
+
+	registry := &fakeRegistry{
+		threshold:    2,
+		signers:      map[common.Address]bool{addr1: true, addr2: true},
+		lastAccepted: 5,
+	}
+
+	if err := Verify(context.Background(), snapshot, registry); err != nil {
+		t.Fatalf("expected snapshot to verify, got: %v", err)
+	}
+
+	t.Run("below threshold fails", func(t *testing.T) {
+		under := *snapshot
+		under.Signatures = [][]byte{sig1}
+		if err := Verify(context.Background(), &under, registry); err == nil {
+			t.Fatal("expected verification to fail with insufficient signatures")
+		}
+	})
+
+	t.Run("replayed epoch fails", func(t *testing.T) {
+		replayed := *snapshot
+		replayed.Epoch = 5
+		if err := Verify(context.Background(), &replayed, registry); err == nil {
+			t.Fatal("expected verification to fail for a non-newer epoch")
+		}
+	})
+}
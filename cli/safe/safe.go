@@ -0,0 +1,101 @@
+// Package safe builds Gnosis Safe Transaction Builder-compatible JSON bundles
+// for pod owners who cannot supply a hot private key.
+package safe
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// Transaction is a single call within a Safe Transaction Builder batch.
+// See: https://github.com/safe-global/safe-react-apps/tree/main/apps/tx-builder
+type Transaction struct {
+	To        string `json:"to"`
+	Value     string `json:"value"`
+	Data      string `json:"data"`
+	Operation uint8  `json:"operation"` // 0 = call, 1 = delegatecall
+}
+
+// Bundle is the top-level document consumed by the Safe Transaction Builder
+// UI/CLI import flow.
+type Bundle struct {
+	Version      string        `json:"version"`
+	ChainId      string        `json:"chainId"`
+	CreatedAt    int64         `json:"createdAt"`
+	Meta         BundleMeta    `json:"meta"`
+	Transactions []Transaction `json:"transactions"`
+}
+
+type BundleMeta struct {
+	Name             string `json:"name"`
+	Description      string `json:"description"`
+	TxBuilderVersion string `json:"txBuilderVersion"`
+}
+
+// NoSendOpts returns TransactOpts that never broadcast; it exists purely so the
+// generated contract bindings can ABI-encode a call on our behalf, as `from`
+// (the Safe address itself, since a multisig has no single private key to sign with).
+func NoSendOpts(from common.Address) *bind.TransactOpts {
+	return &bind.TransactOpts{
+		From:      from,
+		Nonce:     big.NewInt(0),
+		GasFeeCap: big.NewInt(0),
+		GasTipCap: big.NewInt(0),
+		GasLimit:  30_000_000,
+		NoSend:    true,
+		Context:   context.Background(),
+		Signer: func(addr common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			return tx, nil
+		},
+	}
+}
+
+// FromTxn extracts the (to, value, data) triple from an unsent transaction so it
+// can be embedded in a Safe Transaction Builder bundle.
+func FromTxn(txn *types.Transaction) Transaction {
+	to := ""
+	if txn.To() != nil {
+		to = txn.To().Hex()
+	}
+
+	return Transaction{
+		To:        to,
+		Value:     txn.Value().String(),
+		Data:      "0x" + common.Bytes2Hex(txn.Data()),
+		Operation: 0,
+	}
+}
+
+// WriteBundle writes a Safe Transaction Builder-compatible JSON bundle to path.
+func WriteBundle(path string, chainId *big.Int, name string, txns []Transaction) error {
+	if len(txns) == 0 {
+		return errors.New("no transactions to write to safe bundle")
+	}
+
+	bundle := Bundle{
+		Version:   "1.0",
+		ChainId:   chainId.String(),
+		CreatedAt: time.Now().Unix(),
+		Meta: BundleMeta{
+			Name:             name,
+			Description:      "Generated by the eigenproofs CLI",
+			TxBuilderVersion: "1.16.5",
+		},
+		Transactions: txns,
+	}
+
+	out, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal safe transaction bundle")
+	}
+
+	return os.WriteFile(path, out, 0644)
+}
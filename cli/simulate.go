@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+
+	"github.com/Layr-Labs/eigenpod-proofs-generation/cli/safe"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/pkg/errors"
+)
+
+// SimulationResult is the outcome of dry-running a transaction against the
+// latest execution-layer state via eth_call, without broadcasting it.
+type SimulationResult struct {
+	GasUsed      uint64 `json:"gasUsed"`
+	Reverted     bool   `json:"reverted"`
+	RevertReason string `json:"revertReason,omitempty"`
+}
+
+// noSendCallOpts returns TransactOpts that ABI-encode a call but never
+// broadcast, so it can be dry-run with SimulateTransaction instead. This is
+// the same shape of TransactOpts as safe.NoSendOpts, for the same reason:
+// there's no real signer behind `from`, so callers can't get a live-signed
+// transaction out of the generated contract bindings.
+func noSendCallOpts(from common.Address) *bind.TransactOpts {
+	return safe.NoSendOpts(from)
+}
+
+// SimulateTransaction dry-runs txn against the latest block via eth_call,
+// decoding any revert reason and reporting the gas that would have been used.
+func SimulateTransaction(ctx context.Context, eth *ethclient.Client, from common.Address, txn *types.Transaction) (*SimulationResult, error) {
+	msg := ethereum.CallMsg{
+		From:  from,
+		To:    txn.To(),
+		Value: txn.Value(),
+		Data:  txn.Data(),
+	}
+
+	_, callErr := eth.CallContract(ctx, msg, nil)
+	if callErr != nil {
+		return &SimulationResult{
+			Reverted:     true,
+			RevertReason: decodeRevertReason(callErr),
+		}, nil
+	}
+
+	gasUsed, err := eth.EstimateGas(ctx, msg)
+	if err != nil {
+		return nil, errors.Wrap(err, "call succeeded but gas estimation failed")
+	}
+
+	return &SimulationResult{
+		GasUsed:  gasUsed,
+		Reverted: false,
+	}, nil
+}
+
+// decodeRevertReason best-effort unpacks a standard Error(string) revert
+// reason out of an eth_call error, falling back to the raw error text.
+func decodeRevertReason(callErr error) string {
+	data, ok := callErr.(interface{ ErrorData() interface{} })
+	if !ok {
+		return callErr.Error()
+	}
+
+	raw, ok := data.ErrorData().(string)
+	if !ok {
+		return callErr.Error()
+	}
+
+	reasonBytes := common.FromHex(raw)
+	if len(reasonBytes) < 4 {
+		return callErr.Error()
+	}
+
+	reason, err := abi.UnpackRevert(reasonBytes)
+	if err != nil {
+		return callErr.Error()
+	}
+
+	return reason
+}